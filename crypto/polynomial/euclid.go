@@ -0,0 +1,94 @@
+// Copyright © 2020 AMIS Technologies
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package polynomial
+
+import (
+	"errors"
+	"math/big"
+)
+
+var (
+	// ErrNonInvertibleLeadingCoeff is returned if a divisor's leading coefficient has no
+	// inverse mod fieldOrder (e.g. a composite field order).
+	ErrNonInvertibleLeadingCoeff = errors.New("leading coefficient is not invertible mod field order")
+	// ErrNotCoprime is returned by InverseMod if p and m are not coprime, so p has no inverse
+	// mod m.
+	ErrNotCoprime = errors.New("polynomials are not coprime")
+)
+
+// GCD returns the monic greatest common divisor of p and P.
+func (p *Polynomial) GCD(P *Polynomial) (*Polynomial, error) {
+	g, _, _, err := p.ExtGCD(P)
+	if err != nil {
+		return nil, err
+	}
+	return g, nil
+}
+
+// ExtGCD runs the extended polynomial Euclidean algorithm on p and P, returning the monic gcd g
+// together with Bézout coefficients u, v satisfying u·p + v·P = g. It uses the existing FDiv at
+// each step: (r_{i-1}, r_i) -> (r_i, r_{i-1} - q·r_i), with (s, t) updated in parallel, and
+// normalizes the result by the leading coefficient's inverse so g is monic.
+func (p *Polynomial) ExtGCD(P *Polynomial) (g, u, v *Polynomial, err error) {
+	if p.CheckIfValid() != true || P.CheckIfValid() != true {
+		return nil, nil, nil, ErrInvalidPolynomial
+	}
+	one, err := NewPolynomial(p.fieldOrder, []*big.Int{big.NewInt(1)})
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	zero, err := NewPolynomial(p.fieldOrder, []*big.Int{big.NewInt(0)})
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	r0, r1 := p.RemoveZeros(), P.RemoveZeros()
+	s0, s1 := one, zero
+	t0, t1 := zero, one
+	for !r1.CheckIfOnlyZero() {
+		q, r, ferr := r0.FDiv(r1)
+		if ferr != nil {
+			return nil, nil, nil, ferr
+		}
+		sNext := s0.minus(q.mulAuto(s1))
+		tNext := t0.minus(q.mulAuto(t1))
+		r0, r1 = r1, r
+		s0, s1 = s1, sNext
+		t0, t1 = t1, tNext
+	}
+	r0 = r0.RemoveZeros()
+	lc := r0.coefficients[r0.Len()-1]
+	lcInv := new(big.Int).ModInverse(lc, p.fieldOrder)
+	if lcInv == nil {
+		return nil, nil, nil, ErrNonInvertibleLeadingCoeff
+	}
+	return r0.scale(lcInv), s0.scale(lcInv), t0.scale(lcInv), nil
+}
+
+// InverseMod returns p^{-1} mod m, i.e. the polynomial q such that p·q ≡ 1 (mod m). It returns
+// ErrNotCoprime if gcd(p, m) != 1.
+func (p *Polynomial) InverseMod(m *Polynomial) (*Polynomial, error) {
+	g, u, _, err := p.ExtGCD(m)
+	if err != nil {
+		return nil, err
+	}
+	if g.Len() != 1 || g.coefficients[0].Cmp(big.NewInt(1)) != 0 {
+		return nil, ErrNotCoprime
+	}
+	_, r, err := u.FDiv(m)
+	if err != nil {
+		return nil, err
+	}
+	return r, nil
+}
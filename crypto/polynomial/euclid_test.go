@@ -0,0 +1,86 @@
+// Copyright © 2020 AMIS Technologies
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package polynomial
+
+import (
+	"math/big"
+	"testing"
+)
+
+func TestExtGCDBezoutIdentity(t *testing.T) {
+	fieldOrder := big.NewInt(97)
+	p, err := NewPolynomial(fieldOrder, []*big.Int{big.NewInt(1), big.NewInt(1), big.NewInt(1)}) // x^2+x+1
+	if err != nil {
+		t.Fatal(err)
+	}
+	P, err := NewPolynomial(fieldOrder, []*big.Int{big.NewInt(-1), big.NewInt(1)}) // x-1
+	if err != nil {
+		t.Fatal(err)
+	}
+	g, u, v, err := p.ExtGCD(P)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if g.Len() != 1 || g.Get(0).Cmp(big.NewInt(1)) != 0 {
+		t.Fatalf("expected monic gcd 1, got %v", g)
+	}
+	lhs := u.mul(p).add(v.mul(P))
+	if lhs.Len() != 1 || lhs.Get(0).Cmp(big.NewInt(1)) != 0 {
+		t.Fatalf("u*p+v*P != gcd: %v", lhs)
+	}
+}
+
+func TestGCDNonCoprime(t *testing.T) {
+	fieldOrder := big.NewInt(97)
+	// x-1 divides x^2-1, so the gcd should be the monic x-1.
+	p, err := NewPolynomial(fieldOrder, []*big.Int{big.NewInt(-1), big.NewInt(0), big.NewInt(1)}) // x^2-1
+	if err != nil {
+		t.Fatal(err)
+	}
+	P, err := NewPolynomial(fieldOrder, []*big.Int{big.NewInt(-1), big.NewInt(1)}) // x-1
+	if err != nil {
+		t.Fatal(err)
+	}
+	g, err := p.GCD(P)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if g.Len() != 2 || g.Get(0).Cmp(big.NewInt(-1).Mod(big.NewInt(-1), fieldOrder)) != 0 || g.Get(1).Cmp(big.NewInt(1)) != 0 {
+		t.Fatalf("expected gcd x-1, got %v", g)
+	}
+}
+
+func TestInverseMod(t *testing.T) {
+	fieldOrder := big.NewInt(97)
+	m, err := NewPolynomial(fieldOrder, []*big.Int{big.NewInt(1), big.NewInt(0), big.NewInt(1)}) // x^2+1
+	if err != nil {
+		t.Fatal(err)
+	}
+	p, err := NewPolynomial(fieldOrder, []*big.Int{big.NewInt(-3), big.NewInt(1)}) // x-3
+	if err != nil {
+		t.Fatal(err)
+	}
+	inv, err := p.InverseMod(m)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, r, err := p.mul(inv).FDiv(m)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if r.Len() != 1 || r.Get(0).Cmp(big.NewInt(1)) != 0 {
+		t.Fatalf("p*inv mod m != 1, got %v", r)
+	}
+}
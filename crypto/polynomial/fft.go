@@ -0,0 +1,275 @@
+// Copyright © 2020 AMIS Technologies
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package polynomial
+
+import (
+	"errors"
+	"math/big"
+)
+
+var (
+	big1 = big.NewInt(1)
+	// ErrFFTUnsupportedOrder is returned if n does not divide fieldOrder-1, so no primitive n-th
+	// root of unity exists. Callers should fall back to a Kronecker-substitution based
+	// multiplication or the schoolbook path.
+	ErrFFTUnsupportedOrder = errors.New("fft: n does not divide fieldOrder-1, consider a Kronecker-substitution fallback")
+	// ErrNoPrimitiveRoot is returned if no primitive n-th root of unity could be found even
+	// though n divides fieldOrder-1.
+	ErrNoPrimitiveRoot = errors.New("fft: unable to find a primitive root of unity")
+	// ErrMismatchedDomain is returned if two PolynomialValues do not share the same evaluation
+	// domain.
+	ErrMismatchedDomain = errors.New("fft: mismatched evaluation domains")
+	// ErrIndexOutOfRange is returned if an index is out of the valid range.
+	ErrIndexOutOfRange = errors.New("fft: index out of range")
+)
+
+// PolynomialValues represents a polynomial by its evaluations on the n-th roots of unity of
+// F_fieldOrder, i.e. the point-value representation used by the FFT-based fast path.
+type PolynomialValues struct {
+	fieldOrder *big.Int
+	omega      *big.Int
+	values     []*big.Int
+}
+
+// nextPow2 returns the smallest power of 2 that is >= n.
+func nextPow2(n int) int {
+	if n <= 1 {
+		return 1
+	}
+	p := 1
+	for p < n {
+		p <<= 1
+	}
+	return p
+}
+
+// primitiveRootOfUnity finds a primitive n-th root of unity in F_fieldOrder. n must divide
+// fieldOrder-1.
+func primitiveRootOfUnity(fieldOrder *big.Int, n int64) (*big.Int, error) {
+	nBig := big.NewInt(n)
+	pMinus1 := new(big.Int).Sub(fieldOrder, big1)
+	exp, rem := new(big.Int), new(big.Int)
+	exp.DivMod(pMinus1, nBig, rem)
+	if rem.Sign() != 0 {
+		return nil, ErrFFTUnsupportedOrder
+	}
+	if n == 1 {
+		// The only 1st root of unity is 1 itself; the primitivity check below (root^(n/2) != 1)
+		// is meaningless for n == 1 and would otherwise reject every candidate since
+		// g^((fieldOrder-1)/1) always reduces to 1 by Fermat's little theorem.
+		return big1, nil
+	}
+	half := new(big.Int).Div(nBig, big.NewInt(2))
+	for g := int64(2); g < 1000; g++ {
+		base := big.NewInt(g)
+		if base.Cmp(fieldOrder) >= 0 {
+			break
+		}
+		root := new(big.Int).Exp(base, exp, fieldOrder)
+		if root.Cmp(big1) == 0 {
+			continue
+		}
+		if new(big.Int).Exp(root, half, fieldOrder).Cmp(big1) != 0 {
+			return root, nil
+		}
+	}
+	return nil, ErrNoPrimitiveRoot
+}
+
+// ntt computes the number-theoretic transform of a (length must be a power of 2) using omega as
+// the primitive len(a)-th root of unity.
+func ntt(fieldOrder, omega *big.Int, a []*big.Int) []*big.Int {
+	n := len(a)
+	if n == 1 {
+		return []*big.Int{new(big.Int).Mod(a[0], fieldOrder)}
+	}
+	half := n / 2
+	even := make([]*big.Int, half)
+	odd := make([]*big.Int, half)
+	for i := 0; i < half; i++ {
+		even[i] = a[2*i]
+		odd[i] = a[2*i+1]
+	}
+	omega2 := new(big.Int).Mod(new(big.Int).Mul(omega, omega), fieldOrder)
+	evenT := ntt(fieldOrder, omega2, even)
+	oddT := ntt(fieldOrder, omega2, odd)
+	result := make([]*big.Int, n)
+	w := new(big.Int).Set(big1)
+	for i := 0; i < half; i++ {
+		t := new(big.Int).Mod(new(big.Int).Mul(w, oddT[i]), fieldOrder)
+		result[i] = new(big.Int).Mod(new(big.Int).Add(evenT[i], t), fieldOrder)
+		result[i+half] = new(big.Int).Mod(new(big.Int).Sub(evenT[i], t), fieldOrder)
+		w.Mod(new(big.Int).Mul(w, omega), fieldOrder)
+	}
+	return result
+}
+
+// FFT evaluates p, zero-padded to length n (rounded up to the next power of 2), on the n-th roots
+// of unity of F_fieldOrder and returns the point-value representation. It returns
+// ErrFFTUnsupportedOrder if fieldOrder does not admit a primitive n-th root of unity.
+func (p *Polynomial) FFT(n int) (*PolynomialValues, error) {
+	if n < p.Len() {
+		n = p.Len()
+	}
+	n = nextPow2(n)
+	omega, err := primitiveRootOfUnity(p.fieldOrder, int64(n))
+	if err != nil {
+		return nil, err
+	}
+	padded := make([]*big.Int, n)
+	for i := 0; i < n; i++ {
+		if i < p.Len() {
+			padded[i] = new(big.Int).Set(p.coefficients[i])
+		} else {
+			padded[i] = big.NewInt(0)
+		}
+	}
+	return &PolynomialValues{
+		fieldOrder: p.fieldOrder,
+		omega:      omega,
+		values:     ntt(p.fieldOrder, omega, padded),
+	}, nil
+}
+
+// IFFT converts pv back into coefficient form.
+func (pv *PolynomialValues) IFFT() (*Polynomial, error) {
+	n := len(pv.values)
+	invOmega := new(big.Int).ModInverse(pv.omega, pv.fieldOrder)
+	if invOmega == nil {
+		return nil, ErrNoPrimitiveRoot
+	}
+	coefficients := ntt(pv.fieldOrder, invOmega, pv.values)
+	nInv := new(big.Int).ModInverse(big.NewInt(int64(n)), pv.fieldOrder)
+	if nInv == nil {
+		return nil, ErrNoPrimitiveRoot
+	}
+	for i := range coefficients {
+		coefficients[i] = new(big.Int).Mod(new(big.Int).Mul(coefficients[i], nInv), pv.fieldOrder)
+	}
+	result := &Polynomial{
+		fieldOrder:   pv.fieldOrder,
+		coefficients: coefficients,
+	}
+	return result.RemoveZeros(), nil
+}
+
+// Mul multiplies two point-value representations component-wise. Both operands must share the
+// same evaluation domain.
+func (pv *PolynomialValues) Mul(pv2 *PolynomialValues) (*PolynomialValues, error) {
+	if len(pv.values) != len(pv2.values) || pv.omega.Cmp(pv2.omega) != 0 {
+		return nil, ErrMismatchedDomain
+	}
+	values := make([]*big.Int, len(pv.values))
+	for i := range values {
+		values[i] = new(big.Int).Mod(new(big.Int).Mul(pv.values[i], pv2.values[i]), pv.fieldOrder)
+	}
+	return &PolynomialValues{
+		fieldOrder: pv.fieldOrder,
+		omega:      pv.omega,
+		values:     values,
+	}, nil
+}
+
+// Len returns the number of evaluation points.
+func (pv *PolynomialValues) Len() int {
+	return len(pv.values)
+}
+
+// Get gets the ith evaluation. If i is out of range, return nil.
+func (pv *PolynomialValues) Get(i int) *big.Int {
+	if i < 0 || i >= len(pv.values) {
+		return nil
+	}
+	return new(big.Int).Set(pv.values[i])
+}
+
+// Selector returns the point-value vector of length `length` (rounded up to a power of 2) that
+// evaluates to 1 at index i and 0 everywhere else, mirroring plonky2's selector polynomials.
+func Selector(fieldOrder *big.Int, length, i int) (*PolynomialValues, error) {
+	if i < 0 || i >= length {
+		return nil, ErrIndexOutOfRange
+	}
+	n := nextPow2(length)
+	omega, err := primitiveRootOfUnity(fieldOrder, int64(n))
+	if err != nil {
+		return nil, err
+	}
+	values := make([]*big.Int, n)
+	for k := range values {
+		values[k] = big.NewInt(0)
+	}
+	values[i] = big.NewInt(1)
+	return &PolynomialValues{fieldOrder: fieldOrder, omega: omega, values: values}, nil
+}
+
+// Constant returns the point-value vector of length `length` (rounded up to a power of 2) that
+// evaluates to v everywhere, i.e. the point-value form of the constant polynomial v.
+func Constant(fieldOrder, v *big.Int, length int) (*PolynomialValues, error) {
+	n := nextPow2(length)
+	omega, err := primitiveRootOfUnity(fieldOrder, int64(n))
+	if err != nil {
+		return nil, err
+	}
+	vMod := new(big.Int).Mod(v, fieldOrder)
+	values := make([]*big.Int, n)
+	for k := range values {
+		values[k] = new(big.Int).Set(vMod)
+	}
+	return &PolynomialValues{fieldOrder: fieldOrder, omega: omega, values: values}, nil
+}
+
+// MulFFT multiplies p by p2 using an FFT-based convolution instead of schoolbook multiplication.
+// It pads both operands to n = nextPow2(deg p + deg p2 + 1) and requires a primitive n-th root of
+// unity to exist in F_fieldOrder (n | fieldOrder-1), otherwise it returns
+// ErrFFTUnsupportedOrder and callers should fall back to a Kronecker-substitution based
+// multiplication or the schoolbook Mul.
+func (p *Polynomial) MulFFT(p2 *Polynomial) (*Polynomial, error) {
+	// RemoveZeros before validating: callers such as invert() build scratch polynomials with
+	// unset (nil) trailing coefficients, and CheckIfValid dereferences the last coefficient
+	// directly, so validating first would panic on those nils instead of returning
+	// ErrInvalidPolynomial. mul() has the same ordering for the same reason.
+	p = p.RemoveZeros()
+	p2 = p2.RemoveZeros()
+	if p.CheckIfValid() != true || p2.CheckIfValid() != true {
+		return nil, ErrInvalidPolynomial
+	}
+	n := nextPow2(p.Len() + p2.Len() - 1)
+	pv1, err := p.FFT(n)
+	if err != nil {
+		return nil, err
+	}
+	pv2, err := p2.FFT(n)
+	if err != nil {
+		return nil, err
+	}
+	product, err := pv1.Mul(pv2)
+	if err != nil {
+		return nil, err
+	}
+	result, err := product.IFFT()
+	if err != nil {
+		return nil, err
+	}
+	return result.RemoveZeros(), nil
+}
+
+// mulAuto multiplies using the FFT fast path when fieldOrder admits a large enough root of unity
+// for the resulting degree, falling back to schoolbook multiplication otherwise.
+func (p *Polynomial) mulAuto(p2 *Polynomial) *Polynomial {
+	if result, err := p.MulFFT(p2); err == nil {
+		return result
+	}
+	return p.mul(p2)
+}
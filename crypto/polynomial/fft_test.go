@@ -0,0 +1,118 @@
+// Copyright © 2020 AMIS Technologies
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package polynomial
+
+import (
+	"math/big"
+	"testing"
+)
+
+// bn254FrOrder is the BN254 scalar field order. It is chosen because it admits a large (2^28)
+// group of roots of unity, exercising the same FFT fast path used by invert/fDiv in production.
+var bn254FrOrder, _ = new(big.Int).SetString("21888242871839275222246405745257275088548364400416034343698204186575808495617", 10)
+
+func TestMulFFTMatchesSchoolbook(t *testing.T) {
+	p1, err := NewPolynomial(bn254FrOrder, []*big.Int{big.NewInt(1), big.NewInt(2), big.NewInt(3)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	p2, err := NewPolynomial(bn254FrOrder, []*big.Int{big.NewInt(4), big.NewInt(5)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := p1.mul(p2)
+	got, err := p1.MulFFT(p2)
+	if err != nil {
+		t.Fatalf("MulFFT returned error: %v", err)
+	}
+	if got.Len() != want.Len() {
+		t.Fatalf("length mismatch: got %d, want %d", got.Len(), want.Len())
+	}
+	for i := 0; i < want.Len(); i++ {
+		if got.Get(i).Cmp(want.Get(i)) != 0 {
+			t.Fatalf("coefficient %d mismatch: got %s, want %s", i, got.Get(i), want.Get(i))
+		}
+	}
+}
+
+// TestMulFFTConstantPolynomials is a regression test: primitiveRootOfUnity(fieldOrder, 1) used to
+// always fall through to ErrNoPrimitiveRoot, so MulFFT on two constant (degree-0) operands always
+// failed even though n == 1 trivially divides fieldOrder-1.
+func TestMulFFTConstantPolynomials(t *testing.T) {
+	p1, err := NewPolynomial(bn254FrOrder, []*big.Int{big.NewInt(7)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	p2, err := NewPolynomial(bn254FrOrder, []*big.Int{big.NewInt(6)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := p1.MulFFT(p2)
+	if err != nil {
+		t.Fatalf("MulFFT returned error: %v", err)
+	}
+	if got.Get(0).Cmp(big.NewInt(42)) != 0 {
+		t.Fatalf("got %s, want 42", got.Get(0))
+	}
+}
+
+// TestFDivFastPathDoesNotPanic is a regression test: invert()'s scratch polynomials (g0, gi,
+// Just2) contain unset (nil) trailing coefficients, and rewiring invert/fDiv to mulAuto (which
+// goes through the validating MulFFT) used to panic with a nil pointer dereference whenever the
+// fast (non-schoolbook) path was taken, e.g. deg(p)-deg(b) >= SchoolbookThreshold. It also checks
+// the fast path's result against DivModSchoolbook's independently-computed answer, since the
+// Newton loop had a separate off-by-one (g0 lagging a generation behind gi) that produced a
+// mathematically wrong, under-degree quotient/remainder without panicking in some cases.
+func TestFDivFastPathDoesNotPanic(t *testing.T) {
+	coefficients := make([]*big.Int, 200)
+	for i := range coefficients {
+		coefficients[i] = big.NewInt(int64(i + 1))
+	}
+	p, err := NewPolynomial(bn254FrOrder, coefficients)
+	if err != nil {
+		t.Fatal(err)
+	}
+	b, err := NewPolynomial(bn254FrOrder, []*big.Int{big.NewInt(1), big.NewInt(1), big.NewInt(1)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if int(p.Degree())-int(b.Degree()) < SchoolbookThreshold {
+		t.Fatal("test setup does not exercise the fast (non-schoolbook) path")
+	}
+	q, r, err := p.FDiv(b)
+	if err != nil {
+		t.Fatalf("FDiv returned error: %v", err)
+	}
+	if int(r.Degree()) >= int(b.Degree()) {
+		t.Fatalf("remainder degree %d is not < divisor degree %d", r.Degree(), b.Degree())
+	}
+	wantQ, wantR, err := p.DivModSchoolbook(b)
+	if err != nil {
+		t.Fatalf("DivModSchoolbook returned error: %v", err)
+	}
+	for _, x := range []*big.Int{big.NewInt(0), big.NewInt(1), big.NewInt(2), big.NewInt(100), big.NewInt(12345)} {
+		if q.Evaluate(x).Cmp(wantQ.Evaluate(x)) != 0 {
+			t.Fatalf("quotient mismatch at x=%s: got %s, want %s", x, q.Evaluate(x), wantQ.Evaluate(x))
+		}
+		if r.Evaluate(x).Cmp(wantR.Evaluate(x)) != 0 {
+			t.Fatalf("remainder mismatch at x=%s: got %s, want %s", x, r.Evaluate(x), wantR.Evaluate(x))
+		}
+		lhs := new(big.Int).Mod(new(big.Int).Add(new(big.Int).Mul(q.Evaluate(x), b.Evaluate(x)), r.Evaluate(x)), bn254FrOrder)
+		rhs := p.Evaluate(x)
+		if lhs.Cmp(rhs) != 0 {
+			t.Fatalf("q(x)*b(x)+r(x) != p(x) at x=%s: got %s, want %s", x, lhs, rhs)
+		}
+	}
+}
@@ -0,0 +1,116 @@
+// Copyright © 2020 AMIS Technologies
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package polynomial
+
+import (
+	"errors"
+	"math/big"
+
+	"github.com/getamis/alice/crypto/utils"
+)
+
+var (
+	// ErrDuplicateAbscissae is returned if two interpolation points share the same x value.
+	ErrDuplicateAbscissae = errors.New("duplicate abscissae")
+	// ErrMismatchedLength is returned if xs and ys do not have the same length.
+	ErrMismatchedLength = errors.New("mismatched length")
+)
+
+// LagrangeInterpolate returns the unique polynomial of degree <= len(xs)-1 passing through the
+// points (xs[i], ys[i]), using the standard Lagrange basis:
+// L_i(x) = Π_{j≠i} (x - x_j) · (x_i - x_j)^{-1} mod fieldOrder, f(x) = Σ y_i · L_i(x).
+func LagrangeInterpolate(fieldOrder *big.Int, xs, ys []*big.Int) (*Polynomial, error) {
+	if len(xs) != len(ys) {
+		return nil, ErrMismatchedLength
+	}
+	if len(xs) == 0 {
+		return nil, ErrEmptyCoefficients
+	}
+	if err := checkDistinctAbscissae(fieldOrder, xs); err != nil {
+		return nil, err
+	}
+	result, err := NewPolynomial(fieldOrder, []*big.Int{big.NewInt(0)})
+	if err != nil {
+		return nil, err
+	}
+	for i := range xs {
+		li, err := lagrangeBasis(fieldOrder, xs, i)
+		if err != nil {
+			return nil, err
+		}
+		result = result.add(li.scale(ys[i]))
+	}
+	if result.CheckIfOnlyZero() {
+		// RemoveZeros only trims trailing zeros after first trimming trailing nils, so an
+		// all-real-zero accumulation (e.g. an all-zero ys, as happens for an R1CS witness
+		// variable that is unused in a matrix) is never collapsed to length 1. Collapse it
+		// explicitly so CheckIfValid (and callers like qap.combine's Mul) see the canonical
+		// zero polynomial instead of a "non-zero-degree, zero-leading-coefficient" one.
+		return NewPolynomial(fieldOrder, []*big.Int{big.NewInt(0)})
+	}
+	return result, nil
+}
+
+// checkDistinctAbscissae makes sure no two elements of xs are equal mod fieldOrder.
+func checkDistinctAbscissae(fieldOrder *big.Int, xs []*big.Int) error {
+	seen := make(map[string]bool, len(xs))
+	for _, x := range xs {
+		key := new(big.Int).Mod(x, fieldOrder).String()
+		if seen[key] {
+			return ErrDuplicateAbscissae
+		}
+		seen[key] = true
+	}
+	return nil
+}
+
+// lagrangeBasis returns L_i(x) = Π_{j≠i} (x - x_j) · (x_i - x_j)^{-1} mod fieldOrder.
+func lagrangeBasis(fieldOrder *big.Int, xs []*big.Int, i int) (*Polynomial, error) {
+	numerator, err := NewPolynomial(fieldOrder, []*big.Int{big.NewInt(1)})
+	if err != nil {
+		return nil, err
+	}
+	denominator := big.NewInt(1)
+	for j := range xs {
+		if j == i {
+			continue
+		}
+		factor, err := NewPolynomial(fieldOrder, []*big.Int{new(big.Int).Neg(xs[j]), big.NewInt(1)})
+		if err != nil {
+			return nil, err
+		}
+		numerator = numerator.mulAuto(factor)
+		diff := new(big.Int).Mod(new(big.Int).Sub(xs[i], xs[j]), fieldOrder)
+		denominator.Mod(denominator.Mul(denominator, diff), fieldOrder)
+	}
+	denominatorInv := new(big.Int).ModInverse(denominator, fieldOrder)
+	if denominatorInv == nil {
+		return nil, utils.ErrDivisionByZero
+	}
+	return numerator.scale(denominatorInv), nil
+}
+
+// scale multiplies every coefficient of p by c mod fieldOrder.
+func (p *Polynomial) scale(c *big.Int) *Polynomial {
+	newCoefficients := make([]*big.Int, p.Len())
+	for i := range newCoefficients {
+		newCoefficients[i] = new(big.Int).Mod(new(big.Int).Mul(p.coefficients[i], c), p.fieldOrder)
+	}
+	scaled := &Polynomial{
+		fieldOrder:   p.fieldOrder,
+		coefficients: newCoefficients,
+	}
+	return scaled.RemoveZeros()
+}
@@ -0,0 +1,63 @@
+// Copyright © 2020 AMIS Technologies
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package polynomial
+
+import (
+	"math/big"
+	"testing"
+)
+
+func TestLagrangeInterpolateBasic(t *testing.T) {
+	fieldOrder := big.NewInt(97)
+	xs := []*big.Int{big.NewInt(1), big.NewInt(2), big.NewInt(3)}
+	ys := []*big.Int{big.NewInt(1), big.NewInt(4), big.NewInt(9)} // f(x) = x^2
+	p, err := LagrangeInterpolate(fieldOrder, xs, ys)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i, x := range xs {
+		if got := p.Evaluate(x); got.Cmp(ys[i]) != 0 {
+			t.Fatalf("f(%s) = %s, want %s", x, got, ys[i])
+		}
+	}
+}
+
+// TestLagrangeInterpolateAllZeroColumn is a regression test: when every y is zero, the result
+// must collapse to the canonical zero polynomial (length 1), not an all-real-zero polynomial of
+// larger length that fails CheckIfValid.
+func TestLagrangeInterpolateAllZeroColumn(t *testing.T) {
+	fieldOrder := big.NewInt(97)
+	xs := []*big.Int{big.NewInt(1), big.NewInt(2), big.NewInt(3)}
+	ys := []*big.Int{big.NewInt(0), big.NewInt(0), big.NewInt(0)}
+	p, err := LagrangeInterpolate(fieldOrder, xs, ys)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !p.CheckIfValid() {
+		t.Fatalf("all-zero interpolation result is not a valid polynomial: %+v", p)
+	}
+	if p.Len() != 1 {
+		t.Fatalf("all-zero interpolation result did not collapse to length 1: len=%d", p.Len())
+	}
+}
+
+func TestLagrangeInterpolateDuplicateAbscissae(t *testing.T) {
+	fieldOrder := big.NewInt(97)
+	xs := []*big.Int{big.NewInt(1), big.NewInt(1)}
+	ys := []*big.Int{big.NewInt(1), big.NewInt(2)}
+	if _, err := LagrangeInterpolate(fieldOrder, xs, ys); err != ErrDuplicateAbscissae {
+		t.Fatalf("got %v, want ErrDuplicateAbscissae", err)
+	}
+}
@@ -322,11 +322,10 @@ func (p *Polynomial) invert(l *big.Int) *Polynomial {
 	Just2 = Just2.RemoveZeros()
 	gi = (Just2.minus(p)).rem(2)   // initial gi which is g1
 	for i := 1; i <= int(r); i++ { // g0 is g_{i-1} in algorithm 9.3
-		gTemp := gi
-		gi = (Just2.mul(g0).minus(p.mul(g0.mul(g0)))).rem(int(math.Pow(2, float64(i))))
+		gi = (Just2.mulAuto(g0).minus(p.mulAuto(g0.mulAuto(g0)))).rem(int(math.Pow(2, float64(i))))
 		gi = gi.Mod()
 		gi = gi.RemoveZeros()
-		g0 = gTemp
+		g0 = gi // g0 becomes g_i for the next iteration's g_{i-1}
 	}
 	return gi
 }
@@ -361,7 +360,9 @@ func (p *Polynomial) CheckIfOnlyZero() bool {
 	return true
 }
 
-// FDiv is the driver of fDiv
+// FDiv is the driver of fDiv. It routes through DivMod, which picks DivModSchoolbook for small
+// degree gaps and falls back to the Newton-iteration based fDiv otherwise, so callers get correct
+// behavior on small inputs without the Newton overhead.
 func (p *Polynomial) FDiv(b *Polynomial) (q, r *Polynomial, err error) {
 	if p.CheckIfValid() != true || b.CheckIfValid() != true {
 		return nil, nil, ErrInvalidPolynomial
@@ -369,8 +370,7 @@ func (p *Polynomial) FDiv(b *Polynomial) (q, r *Polynomial, err error) {
 	if b.CheckIfOnlyZero() {
 		return nil, nil, utils.ErrDivisionByZero
 	}
-	q, r = p.fDiv(b)
-	return q, r, nil
+	return p.DivMod(b)
 }
 
 // FDiv (algorithm 9.5) means fast division with remainder, it performs division between polynomials with smaller complexity than the normal one
@@ -396,8 +396,8 @@ func (p *Polynomial) fDiv(b *Polynomial) (q, r *Polynomial) {
 	l := big.NewInt(int64(m) + 1)
 	RevB := b.rev(b.Degree())
 	invRevB := RevB.invert(l)
-	qAsterisk := p.rev(p.Degree()).mul(invRevB).rem(int(m) + 1)
+	qAsterisk := p.rev(p.Degree()).mulAuto(invRevB).rem(int(m) + 1)
 	q = qAsterisk.rev(m)
-	r = p.minus(b.mul(q))
+	r = p.minus(b.mulAuto(q))
 	return q, r
 }
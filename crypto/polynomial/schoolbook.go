@@ -0,0 +1,93 @@
+// Copyright © 2020 AMIS Technologies
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package polynomial
+
+import (
+	"math/big"
+
+	"github.com/getamis/alice/crypto/utils"
+)
+
+// SchoolbookThreshold is the degree gap (deg p - deg b) below which DivMod uses
+// DivModSchoolbook instead of the Newton-iteration based fDiv. fDiv allocates scratch
+// polynomials of size l+2 regardless of how small b is, so for small divisors the direct
+// algorithm is both cheaper and avoids relying on RevB being invertible mod x.
+var SchoolbookThreshold = 64
+
+// DivModSchoolbook implements the direct long-division algorithm: repeatedly take the leading
+// coefficient of the remainder, multiply b shifted right by (deg(remainder)-deg(b)) by
+// lc(remainder)·lc(b)^{-1} mod fieldOrder, subtract from the remainder, and record the quotient
+// coefficient. It returns ErrNonInvertibleLeadingCoeff if b's leading coefficient has no inverse
+// mod fieldOrder.
+func (p *Polynomial) DivModSchoolbook(b *Polynomial) (q, r *Polynomial, err error) {
+	if p.CheckIfValid() != true || b.CheckIfValid() != true {
+		return nil, nil, ErrInvalidPolynomial
+	}
+	b = b.RemoveZeros()
+	if b.CheckIfOnlyZero() {
+		return nil, nil, utils.ErrDivisionByZero
+	}
+	lcInv := new(big.Int).ModInverse(b.coefficients[b.Len()-1], p.fieldOrder)
+	if lcInv == nil {
+		return nil, nil, ErrNonInvertibleLeadingCoeff
+	}
+	remainder := p.RemoveZeros()
+	qDegree := int(remainder.Degree()) - int(b.Degree())
+	if qDegree < 0 {
+		qDegree = 0
+	}
+	qCoefficients := make([]*big.Int, qDegree+1)
+	for i := range qCoefficients {
+		qCoefficients[i] = big.NewInt(0)
+	}
+	for !remainder.CheckIfOnlyZero() && int(remainder.Degree()) >= int(b.Degree()) {
+		shift := int(remainder.Degree()) - int(b.Degree())
+		coeff := new(big.Int).Mod(new(big.Int).Mul(remainder.coefficients[remainder.Len()-1], lcInv), p.fieldOrder)
+		qCoefficients[shift] = coeff
+		shiftedCoefficients := make([]*big.Int, shift+b.Len())
+		for i := range shiftedCoefficients {
+			shiftedCoefficients[i] = big.NewInt(0)
+		}
+		for i, c := range b.coefficients {
+			shiftedCoefficients[i+shift] = new(big.Int).Mod(new(big.Int).Mul(c, coeff), p.fieldOrder)
+		}
+		shifted := &Polynomial{fieldOrder: p.fieldOrder, coefficients: shiftedCoefficients}
+		remainder = remainder.minus(shifted)
+	}
+	quotient := &Polynomial{fieldOrder: p.fieldOrder, coefficients: qCoefficients}
+	return quotient.RemoveZeros(), remainder, nil
+}
+
+// DivMod dispatches to DivModSchoolbook when the degree gap (deg p - deg b) is below
+// SchoolbookThreshold, and to the Newton-iteration based fDiv otherwise. It returns
+// ErrNonInvertibleLeadingCoeff if b's leading coefficient has no inverse mod fieldOrder (e.g.
+// composite field orders used in tests).
+func (p *Polynomial) DivMod(b *Polynomial) (q, r *Polynomial, err error) {
+	if p.CheckIfValid() != true || b.CheckIfValid() != true {
+		return nil, nil, ErrInvalidPolynomial
+	}
+	b = b.RemoveZeros()
+	if b.CheckIfOnlyZero() {
+		return nil, nil, utils.ErrDivisionByZero
+	}
+	if new(big.Int).ModInverse(b.coefficients[b.Len()-1], p.fieldOrder) == nil {
+		return nil, nil, ErrNonInvertibleLeadingCoeff
+	}
+	if int(p.Degree())-int(b.Degree()) < SchoolbookThreshold {
+		return p.DivModSchoolbook(b)
+	}
+	q, r = p.fDiv(b)
+	return q, r, nil
+}
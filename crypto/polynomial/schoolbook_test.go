@@ -0,0 +1,151 @@
+// Copyright © 2020 AMIS Technologies
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package polynomial
+
+import (
+	"math/big"
+	"testing"
+)
+
+func TestDivModSchoolbookMatchesFDiv(t *testing.T) {
+	fieldOrder := big.NewInt(97)
+	coefficients := make([]*big.Int, 10)
+	for i := range coefficients {
+		coefficients[i] = big.NewInt(int64(i + 1))
+	}
+	p, err := NewPolynomial(fieldOrder, coefficients)
+	if err != nil {
+		t.Fatal(err)
+	}
+	b, err := NewPolynomial(fieldOrder, []*big.Int{big.NewInt(1), big.NewInt(1), big.NewInt(1)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	wantQ, wantR := p.fDiv(b)
+	gotQ, gotR, err := p.DivModSchoolbook(b)
+	if err != nil {
+		t.Fatalf("DivModSchoolbook returned error: %v", err)
+	}
+	for _, x := range []*big.Int{big.NewInt(0), big.NewInt(1), big.NewInt(2), big.NewInt(5), big.NewInt(50)} {
+		if gotQ.Evaluate(x).Cmp(wantQ.Evaluate(x)) != 0 {
+			t.Fatalf("quotient mismatch at x=%s: got %s, want %s", x, gotQ.Evaluate(x), wantQ.Evaluate(x))
+		}
+		if gotR.Evaluate(x).Cmp(wantR.Evaluate(x)) != 0 {
+			t.Fatalf("remainder mismatch at x=%s: got %s, want %s", x, gotR.Evaluate(x), wantR.Evaluate(x))
+		}
+	}
+}
+
+func TestDivModDispatchesOnThreshold(t *testing.T) {
+	fieldOrder := big.NewInt(97)
+	coefficients := make([]*big.Int, 10)
+	for i := range coefficients {
+		coefficients[i] = big.NewInt(int64(i + 1))
+	}
+	p, err := NewPolynomial(fieldOrder, coefficients)
+	if err != nil {
+		t.Fatal(err)
+	}
+	b, err := NewPolynomial(fieldOrder, []*big.Int{big.NewInt(1), big.NewInt(1), big.NewInt(1)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	original := SchoolbookThreshold
+	defer func() { SchoolbookThreshold = original }()
+
+	SchoolbookThreshold = 1000 // degree gap (7) stays below threshold: schoolbook path
+	q, r, err := p.DivMod(b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	schoolQ, schoolR, err := p.DivModSchoolbook(b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if q.Len() != schoolQ.Len() || r.Len() != schoolR.Len() {
+		t.Fatalf("DivMod did not take the schoolbook path as expected")
+	}
+
+	SchoolbookThreshold = 0 // degree gap (7) is now above threshold: Newton-iteration path
+	fastQ, fastR := p.fDiv(b)
+	q, r, err = p.DivMod(b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, x := range []*big.Int{big.NewInt(0), big.NewInt(1), big.NewInt(5)} {
+		if q.Evaluate(x).Cmp(fastQ.Evaluate(x)) != 0 || r.Evaluate(x).Cmp(fastR.Evaluate(x)) != 0 {
+			t.Fatalf("DivMod did not agree with the Newton-iteration path at x=%s", x)
+		}
+	}
+}
+
+// TestDivModFastPathMatchesSchoolbook is a regression test: DivMod routes any degree gap >=
+// SchoolbookThreshold to the Newton-iteration fDiv path, which previously had an off-by-one in
+// invert() that made it return a mathematically wrong, under-degree quotient/remainder (not
+// merely "disagrees with itself" as TestDivModDispatchesOnThreshold checks) once the gap got
+// large enough. This compares DivMod's default (fast-path) output against DivModSchoolbook, an
+// independently implemented algorithm, at the default SchoolbookThreshold.
+func TestDivModFastPathMatchesSchoolbook(t *testing.T) {
+	fieldOrder := big.NewInt(97)
+	coefficients := make([]*big.Int, 200)
+	for i := range coefficients {
+		coefficients[i] = big.NewInt(int64(i + 1))
+	}
+	p, err := NewPolynomial(fieldOrder, coefficients)
+	if err != nil {
+		t.Fatal(err)
+	}
+	b, err := NewPolynomial(fieldOrder, []*big.Int{big.NewInt(1), big.NewInt(1), big.NewInt(1)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if int(p.Degree())-int(b.Degree()) < SchoolbookThreshold {
+		t.Fatal("test setup does not exercise the fast (non-schoolbook) path")
+	}
+	q, r, err := p.DivMod(b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	wantQ, wantR, err := p.DivModSchoolbook(b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, x := range []*big.Int{big.NewInt(0), big.NewInt(1), big.NewInt(2), big.NewInt(50), big.NewInt(96)} {
+		if q.Evaluate(x).Cmp(wantQ.Evaluate(x)) != 0 {
+			t.Fatalf("quotient mismatch at x=%s: got %s, want %s", x, q.Evaluate(x), wantQ.Evaluate(x))
+		}
+		if r.Evaluate(x).Cmp(wantR.Evaluate(x)) != 0 {
+			t.Fatalf("remainder mismatch at x=%s: got %s, want %s", x, r.Evaluate(x), wantR.Evaluate(x))
+		}
+	}
+}
+
+func TestDivModNonInvertibleLeadingCoeff(t *testing.T) {
+	fieldOrder := big.NewInt(6) // composite: 2 has no inverse mod 6
+	p, err := NewPolynomial(fieldOrder, []*big.Int{big.NewInt(1), big.NewInt(1), big.NewInt(1)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	b, err := NewPolynomial(fieldOrder, []*big.Int{big.NewInt(1), big.NewInt(2)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, _, err := p.DivMod(b); err != ErrNonInvertibleLeadingCoeff {
+		t.Fatalf("got %v, want ErrNonInvertibleLeadingCoeff", err)
+	}
+	if _, _, err := p.DivModSchoolbook(b); err != ErrNonInvertibleLeadingCoeff {
+		t.Fatalf("got %v, want ErrNonInvertibleLeadingCoeff", err)
+	}
+}
@@ -0,0 +1,165 @@
+// Copyright © 2020 AMIS Technologies
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package polynomial
+
+import "math/big"
+
+// subproductTree is the binary tree of linear factors M_{i,j}(x) = Π (x - x_k) used by the fast
+// multi-point evaluation and interpolation algorithms (Modern Computer Algebra, algorithms
+// 10.5/10.7). layers[0] holds the leaves (x - x_k), padded with identity factors (the constant
+// polynomial 1) up to the next power of 2 so every internal node has exactly two children;
+// layers[len(layers)-1] holds the single root M(x) = Π (x - x_k).
+type subproductTree struct {
+	layers [][]*Polynomial
+	n      int // number of real points (before padding)
+}
+
+// buildSubproductTree builds the subproduct tree of the linear factors (x - x_k) for xs, padding
+// with identity leaves up to the next power of 2.
+func buildSubproductTree(fieldOrder *big.Int, xs []*big.Int) *subproductTree {
+	n := len(xs)
+	size := nextPow2(n)
+	leaves := make([]*Polynomial, size)
+	for i := 0; i < size; i++ {
+		if i < n {
+			leaves[i], _ = NewPolynomial(fieldOrder, []*big.Int{new(big.Int).Neg(xs[i]), big.NewInt(1)})
+		} else {
+			leaves[i], _ = NewPolynomial(fieldOrder, []*big.Int{big.NewInt(1)})
+		}
+	}
+	layers := [][]*Polynomial{leaves}
+	current := leaves
+	for len(current) > 1 {
+		next := make([]*Polynomial, len(current)/2)
+		for j := range next {
+			next[j] = current[2*j].mulAuto(current[2*j+1])
+		}
+		layers = append(layers, next)
+		current = next
+	}
+	return &subproductTree{layers: layers, n: n}
+}
+
+// EvaluateAll evaluates p at every point in xs using the subproduct tree (algorithm 10.5):
+// f is repeatedly reduced modulo the subproduct tree nodes on the way down to the leaves, where
+// the remaining degree-0 remainder is f(x_k).
+func (p *Polynomial) EvaluateAll(xs []*big.Int) ([]*big.Int, error) {
+	if len(xs) == 0 {
+		return nil, nil
+	}
+	tree := buildSubproductTree(p.fieldOrder, xs)
+	top := tree.layers[len(tree.layers)-1][0]
+	_, remainder, err := p.FDiv(top)
+	if err != nil {
+		return nil, err
+	}
+	values := make([]*big.Int, 0, tree.n)
+	if err := tree.evaluateRec(remainder, len(tree.layers)-1, 0, &values); err != nil {
+		return nil, err
+	}
+	return values, nil
+}
+
+// evaluateRec descends the tree from (level, index), reducing f modulo each child, and appends
+// the point values of the real leaves (in index order) to values.
+func (t *subproductTree) evaluateRec(f *Polynomial, level, index int, values *[]*big.Int) error {
+	if level == 0 {
+		if index >= t.n {
+			return nil
+		}
+		v := f.Get(0)
+		if v == nil {
+			v = big.NewInt(0)
+		}
+		*values = append(*values, v)
+		return nil
+	}
+	if f.CheckIfOnlyZero() {
+		// FDiv rejects an all-zero polynomial longer than one coefficient; use the canonical zero.
+		f, _ = NewPolynomial(f.fieldOrder, []*big.Int{big.NewInt(0)})
+	}
+	children := t.layers[level-1]
+	left, right := children[2*index], children[2*index+1]
+	_, fLeft, err := f.FDiv(left)
+	if err != nil {
+		return err
+	}
+	_, fRight, err := f.FDiv(right)
+	if err != nil {
+		return err
+	}
+	if err := t.evaluateRec(fLeft, level-1, 2*index, values); err != nil {
+		return err
+	}
+	return t.evaluateRec(fRight, level-1, 2*index+1, values)
+}
+
+// InterpolateFast interpolates the unique polynomial of degree < len(xs) through (xs, ys) in
+// O(M(n) log n) using a subproduct tree (algorithm 10.7), instead of the O(n^2) naive Lagrange
+// approach. It computes s_k = 1/M'(x_k), where M = Π (x - x_k), via fast multi-point evaluation
+// of M's derivative, then combines bottom-up:
+// r_{i+1,j}(x) = M_{i,2j+1}·r_{i,2j} + M_{i,2j}·r_{i,2j+1}, starting from r_{0,k} = s_k·y_k.
+func InterpolateFast(fieldOrder *big.Int, xs, ys []*big.Int) (*Polynomial, error) {
+	if len(xs) != len(ys) {
+		return nil, ErrMismatchedLength
+	}
+	n := len(xs)
+	if n == 0 {
+		return nil, ErrEmptyCoefficients
+	}
+	if err := checkDistinctAbscissae(fieldOrder, xs); err != nil {
+		return nil, err
+	}
+	tree := buildSubproductTree(fieldOrder, xs)
+	top := tree.layers[len(tree.layers)-1][0]
+	mPrime := top.Differentiate(1)
+	sValues, err := mPrime.EvaluateAll(xs)
+	if err != nil {
+		return nil, err
+	}
+
+	size := len(tree.layers[0])
+	current := make([]*Polynomial, size)
+	for k := 0; k < size; k++ {
+		if k < n {
+			sInv := new(big.Int).ModInverse(sValues[k], fieldOrder)
+			if sInv == nil {
+				return nil, ErrDuplicateAbscissae
+			}
+			v := new(big.Int).Mod(new(big.Int).Mul(sInv, ys[k]), fieldOrder)
+			current[k], _ = NewPolynomial(fieldOrder, []*big.Int{v})
+		} else {
+			current[k], _ = NewPolynomial(fieldOrder, []*big.Int{big.NewInt(0)})
+		}
+	}
+	for level := 0; len(current) > 1; level++ {
+		mLayer := tree.layers[level]
+		next := make([]*Polynomial, len(current)/2)
+		for j := range next {
+			left, right := mLayer[2*j], mLayer[2*j+1]
+			term1 := right.mulAuto(current[2*j])
+			term2 := left.mulAuto(current[2*j+1])
+			next[j] = term1.add(term2)
+		}
+		current = next
+	}
+	result := current[0]
+	if result.CheckIfOnlyZero() {
+		// Same degenerate case as LagrangeInterpolate: an all-zero ys must collapse to the
+		// canonical zero polynomial rather than an all-real-zero one RemoveZeros never trims.
+		return NewPolynomial(fieldOrder, []*big.Int{big.NewInt(0)})
+	}
+	return result, nil
+}
@@ -0,0 +1,74 @@
+// Copyright © 2020 AMIS Technologies
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package polynomial
+
+import (
+	"math/big"
+	"testing"
+)
+
+func TestEvaluateAllMatchesEvaluate(t *testing.T) {
+	fieldOrder := big.NewInt(97)
+	p, err := NewPolynomial(fieldOrder, []*big.Int{big.NewInt(1), big.NewInt(2), big.NewInt(3), big.NewInt(4)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	xs := []*big.Int{big.NewInt(1), big.NewInt(2), big.NewInt(5), big.NewInt(10), big.NewInt(20)}
+	got, err := p.EvaluateAll(xs)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i, x := range xs {
+		if want := p.Evaluate(x); got[i].Cmp(want) != 0 {
+			t.Fatalf("EvaluateAll[%d] = %s, want %s", i, got[i], want)
+		}
+	}
+}
+
+func TestInterpolateFastMatchesLagrangeInterpolate(t *testing.T) {
+	fieldOrder := big.NewInt(97)
+	xs := []*big.Int{big.NewInt(1), big.NewInt(2), big.NewInt(3), big.NewInt(4), big.NewInt(5)}
+	ys := []*big.Int{big.NewInt(3), big.NewInt(8), big.NewInt(17), big.NewInt(30), big.NewInt(47)} // f(x) = 2x^2+1
+	want, err := LagrangeInterpolate(fieldOrder, xs, ys)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := InterpolateFast(fieldOrder, xs, ys)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, x := range xs {
+		if got.Evaluate(x).Cmp(want.Evaluate(x)) != 0 {
+			t.Fatalf("InterpolateFast disagrees with LagrangeInterpolate at x=%s", x)
+		}
+	}
+}
+
+// TestEvaluateAllPropagatesErrors is a regression test: EvaluateAll/evaluateRec used to swallow
+// FDiv errors and silently substitute the un-reduced polynomial, returning plausible-but-wrong
+// point values instead of surfacing the failure.
+func TestEvaluateAllPropagatesErrors(t *testing.T) {
+	fieldOrder := big.NewInt(5)
+	// The leading coefficient (5) reduces to 0 mod 5, so this Polynomial fails CheckIfValid
+	// and FDiv must reject it instead of being treated as an ordinary dividend.
+	p, err := NewPolynomial(fieldOrder, []*big.Int{big.NewInt(1), big.NewInt(1), big.NewInt(5)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	xs := []*big.Int{big.NewInt(0), big.NewInt(1), big.NewInt(3)}
+	if _, err := p.EvaluateAll(xs); err == nil {
+		t.Fatal("expected EvaluateAll to surface the division error, got nil")
+	}
+}
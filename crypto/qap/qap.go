@@ -0,0 +1,203 @@
+// Copyright © 2020 AMIS Technologies
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package qap converts a rank-1 constraint system (R1CS) into a quadratic arithmetic program
+// (QAP), the algebraic backbone of a Groth16-style prover: a witness satisfies the R1CS iff its
+// QAP polynomials divide evenly by the target polynomial Z.
+package qap
+
+import (
+	"errors"
+	"math/big"
+
+	"github.com/getamis/alice/crypto/polynomial"
+)
+
+var (
+	// ErrEmptyConstraints is returned if A, B, C describe zero constraints.
+	ErrEmptyConstraints = errors.New("empty constraints")
+	// ErrMismatchedMatrices is returned if A, B, C do not describe the same number of
+	// constraints and witness variables.
+	ErrMismatchedMatrices = errors.New("mismatched R1CS matrices")
+	// ErrMismatchedWitness is returned if the witness length does not match the number of
+	// witness variables in the R1CS.
+	ErrMismatchedWitness = errors.New("mismatched witness length")
+	// ErrWitnessDoesNotSatisfyQAP is returned by Divide if A(x)·B(x)-C(x) is not evenly
+	// divisible by Z(x), i.e. the witness does not satisfy the R1CS.
+	ErrWitnessDoesNotSatisfyQAP = errors.New("witness does not satisfy the QAP")
+)
+
+// QAP is a quadratic arithmetic program derived from an R1CS (A, B, C) by interpolating each
+// witness variable's column across the constraint indices 1..m.
+type QAP struct {
+	fieldOrder *big.Int
+	Ai         []*polynomial.Polynomial
+	Bi         []*polynomial.Polynomial
+	Ci         []*polynomial.Polynomial
+	Z          *polynomial.Polynomial
+}
+
+// NewQAP builds a QAP from an R1CS given by the matrices A, B, C ([]row, each row []*big.Int
+// indexed by witness variable). Each matrix must have m rows (constraints) and n columns
+// (witness variables).
+func NewQAP(fieldOrder *big.Int, A, B, C [][]*big.Int) (*QAP, error) {
+	m := len(A)
+	if m == 0 {
+		return nil, ErrEmptyConstraints
+	}
+	if len(B) != m || len(C) != m {
+		return nil, ErrMismatchedMatrices
+	}
+	n := len(A[0])
+	xs := make([]*big.Int, m)
+	for k := 0; k < m; k++ {
+		xs[k] = big.NewInt(int64(k + 1))
+	}
+	Ai, err := interpolateColumns(fieldOrder, A, xs, n)
+	if err != nil {
+		return nil, err
+	}
+	Bi, err := interpolateColumns(fieldOrder, B, xs, n)
+	if err != nil {
+		return nil, err
+	}
+	Ci, err := interpolateColumns(fieldOrder, C, xs, n)
+	if err != nil {
+		return nil, err
+	}
+	Z, err := targetPolynomial(fieldOrder, m)
+	if err != nil {
+		return nil, err
+	}
+	return &QAP{
+		fieldOrder: fieldOrder,
+		Ai:         Ai,
+		Bi:         Bi,
+		Ci:         Ci,
+		Z:          Z,
+	}, nil
+}
+
+// interpolateColumns interpolates one polynomial per witness variable (column of M), across the
+// constraint indices xs.
+func interpolateColumns(fieldOrder *big.Int, M [][]*big.Int, xs []*big.Int, n int) ([]*polynomial.Polynomial, error) {
+	result := make([]*polynomial.Polynomial, n)
+	for v := 0; v < n; v++ {
+		ys := make([]*big.Int, len(M))
+		for k, row := range M {
+			if len(row) != n {
+				return nil, ErrMismatchedMatrices
+			}
+			ys[k] = row[v]
+		}
+		p, err := polynomial.LagrangeInterpolate(fieldOrder, xs, ys)
+		if err != nil {
+			return nil, err
+		}
+		result[v] = p
+	}
+	return result, nil
+}
+
+// targetPolynomial returns Z(x) = Π_{k=1..m} (x - k).
+func targetPolynomial(fieldOrder *big.Int, m int) (*polynomial.Polynomial, error) {
+	z, err := polynomial.NewPolynomial(fieldOrder, []*big.Int{big.NewInt(1)})
+	if err != nil {
+		return nil, err
+	}
+	for k := 1; k <= m; k++ {
+		factor, err := polynomial.NewPolynomial(fieldOrder, []*big.Int{big.NewInt(int64(-k)), big.NewInt(1)})
+		if err != nil {
+			return nil, err
+		}
+		z, err = z.Mul(factor)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return z, nil
+}
+
+// combine evaluates Σ_v witness[v]·polys[v] for the given per-variable polynomials and witness
+// assignment.
+func (q *QAP) combine(polys []*polynomial.Polynomial, witness []*big.Int) (*polynomial.Polynomial, error) {
+	if len(polys) != len(witness) {
+		return nil, ErrMismatchedWitness
+	}
+	sum, err := polynomial.NewPolynomial(q.fieldOrder, []*big.Int{big.NewInt(0)})
+	if err != nil {
+		return nil, err
+	}
+	for i, p := range polys {
+		wi, err := polynomial.NewPolynomial(q.fieldOrder, []*big.Int{witness[i]})
+		if err != nil {
+			return nil, err
+		}
+		term, err := p.Mul(wi)
+		if err != nil {
+			return nil, err
+		}
+		sum, err = sum.Add(term)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return sum, nil
+}
+
+// Divide returns H(x) such that A(x)·B(x) - C(x) = H(x)·Z(x) for the given witness assignment,
+// where A, B, C are Σ_v witness[v]·Ai[v] (resp. Bi, Ci). It returns ErrWitnessDoesNotSatisfyQAP
+// if the witness does not satisfy the R1CS, i.e. the division leaves a non-zero remainder.
+func (q *QAP) Divide(witness []*big.Int) (*polynomial.Polynomial, error) {
+	A, err := q.combine(q.Ai, witness)
+	if err != nil {
+		return nil, err
+	}
+	B, err := q.combine(q.Bi, witness)
+	if err != nil {
+		return nil, err
+	}
+	C, err := q.combine(q.Ci, witness)
+	if err != nil {
+		return nil, err
+	}
+	AB, err := A.Mul(B)
+	if err != nil {
+		return nil, err
+	}
+	diff, err := AB.Minus(C)
+	if err != nil {
+		return nil, err
+	}
+	H, r, err := diff.FDiv(q.Z)
+	if err != nil {
+		return nil, err
+	}
+	if !r.CheckIfOnlyZero() {
+		return nil, ErrWitnessDoesNotSatisfyQAP
+	}
+	return H, nil
+}
+
+// Verify reports whether witness satisfies the R1CS this QAP was built from.
+func (q *QAP) Verify(witness []*big.Int) (bool, error) {
+	_, err := q.Divide(witness)
+	if err == ErrWitnessDoesNotSatisfyQAP {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return true, nil
+}
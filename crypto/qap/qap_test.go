@@ -0,0 +1,91 @@
+// Copyright © 2020 AMIS Technologies
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package qap
+
+import (
+	"math/big"
+	"testing"
+)
+
+// squareR1CS returns a single constraint x*x = y over the witness [1, x, y].
+func squareR1CS() ([][]*big.Int, [][]*big.Int, [][]*big.Int) {
+	A := [][]*big.Int{{big.NewInt(0), big.NewInt(1), big.NewInt(0)}}
+	B := [][]*big.Int{{big.NewInt(0), big.NewInt(1), big.NewInt(0)}}
+	C := [][]*big.Int{{big.NewInt(0), big.NewInt(0), big.NewInt(1)}}
+	return A, B, C
+}
+
+func TestQAPVerifySatisfyingWitness(t *testing.T) {
+	fieldOrder := big.NewInt(97)
+	A, B, C := squareR1CS()
+	q, err := NewQAP(fieldOrder, A, B, C)
+	if err != nil {
+		t.Fatal(err)
+	}
+	witness := []*big.Int{big.NewInt(1), big.NewInt(3), big.NewInt(9)}
+	ok, err := q.Verify(witness)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !ok {
+		t.Fatal("expected witness to satisfy the QAP")
+	}
+}
+
+func TestQAPVerifyRejectsBadWitness(t *testing.T) {
+	fieldOrder := big.NewInt(97)
+	A, B, C := squareR1CS()
+	q, err := NewQAP(fieldOrder, A, B, C)
+	if err != nil {
+		t.Fatal(err)
+	}
+	witness := []*big.Int{big.NewInt(1), big.NewInt(3), big.NewInt(10)}
+	ok, err := q.Verify(witness)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ok {
+		t.Fatal("expected witness to fail the QAP check")
+	}
+}
+
+// TestQAPWithZeroColumn is a regression test: a witness variable unused in one of the R1CS
+// matrices produces an all-zero column, which previously made LagrangeInterpolate return a
+// polynomial that failed CheckIfValid inside combine, so Verify errored instead of evaluating
+// the witness.
+func TestQAPWithZeroColumn(t *testing.T) {
+	fieldOrder := big.NewInt(97)
+	const m = 70
+	A := make([][]*big.Int, m)
+	B := make([][]*big.Int, m)
+	C := make([][]*big.Int, m)
+	for k := 0; k < m; k++ {
+		A[k] = []*big.Int{big.NewInt(0), big.NewInt(1), big.NewInt(0)}
+		B[k] = []*big.Int{big.NewInt(0), big.NewInt(1), big.NewInt(0)} // column 2 is all zero
+		C[k] = []*big.Int{big.NewInt(0), big.NewInt(0), big.NewInt(1)}
+	}
+	q, err := NewQAP(fieldOrder, A, B, C)
+	if err != nil {
+		t.Fatal(err)
+	}
+	witness := []*big.Int{big.NewInt(1), big.NewInt(3), big.NewInt(9)}
+	ok, err := q.Verify(witness)
+	if err != nil {
+		t.Fatalf("Verify returned an error instead of evaluating the witness: %v", err)
+	}
+	if !ok {
+		t.Fatal("expected witness to satisfy the QAP")
+	}
+}